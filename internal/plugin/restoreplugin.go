@@ -46,7 +46,7 @@ func (p *RestorePlugin) Execute(input *velero.RestoreItemActionExecuteInput) (*v
 
 	metadata, err := meta.Accessor(input.Item)
 	if err != nil {
-		return &velero.RestoreItemActionExecuteOutput{}, err
+		return velero.NewRestoreItemActionExecuteOutput(input.Item), err
 	}
 
 	annotations := metadata.GetAnnotations()
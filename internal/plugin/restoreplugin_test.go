@@ -0,0 +1,74 @@
+/*
+Copyright 2018, 2019 the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vmware-tanzu/velero/pkg/plugin/velero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// noMetadataItem implements runtime.Unstructured but not metav1.Object,
+// so meta.Accessor fails on it.
+type noMetadataItem struct{}
+
+func (i *noMetadataItem) UnstructuredContent() map[string]interface{}   { return nil }
+func (i *noMetadataItem) SetUnstructuredContent(map[string]interface{}) {}
+func (i *noMetadataItem) IsList() bool                                  { return false }
+func (i *noMetadataItem) EachListItem(func(runtime.Object) error) error { return nil }
+func (i *noMetadataItem) NewEmptyInstance() runtime.Unstructured        { return &noMetadataItem{} }
+func (i *noMetadataItem) GetObjectKind() schema.ObjectKind              { return schema.EmptyObjectKind }
+func (i *noMetadataItem) DeepCopyObject() runtime.Object                { return &noMetadataItem{} }
+
+func TestRestorePluginExecuteAccessorError(t *testing.T) {
+	item := &noMetadataItem{}
+	input := &velero.RestoreItemActionExecuteInput{Item: item}
+
+	output, err := NewRestorePlugin(logrus.New()).Execute(input)
+	if err == nil {
+		t.Fatal("expected an error for an item without accessible metadata")
+	}
+	if output == nil {
+		t.Fatal("expected a non-nil output")
+	}
+	if output.UpdatedItem != input.Item {
+		t.Errorf("expected UpdatedItem to be the input item, got %v", output.UpdatedItem)
+	}
+}
+
+func TestRestorePluginExecuteSetsAnnotation(t *testing.T) {
+	item := &unstructured.Unstructured{}
+	item.SetName("test")
+	input := &velero.RestoreItemActionExecuteInput{Item: item}
+
+	output, err := NewRestorePlugin(logrus.New()).Execute(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, ok := output.UpdatedItem.(*unstructured.Unstructured)
+	if !ok {
+		t.Fatalf("expected *unstructured.Unstructured, got %T", output.UpdatedItem)
+	}
+	if got := updated.GetAnnotations()["velero.io/my-restore-plugin"]; got != "1" {
+		t.Errorf("expected annotation velero.io/my-restore-plugin=1, got %q", got)
+	}
+}